@@ -0,0 +1,120 @@
+// British/American -ise/-ize normalisation for go-stemmer
+// Developed by Suhail Patel <me@suhailpatel.com>
+//
+// Copyright (C) 2013 Suhail Patel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this
+// software and associated documentation files (the "Software"), to deal in the Software
+// without restriction, including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons
+// to whom the Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or
+// substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+// PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR
+// ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// British English favours -ise where American English uses -ize (organise vs
+// organize, realisation vs realization). Left alone, the two spellings stem
+// to different results even though they're the same word, which splits an
+// index built over a mixed-spelling corpus. StemWith(word, opts) lets callers
+// opt into rewriting the British form to its American equivalent before
+// stemming, so both spellings collapse onto the same stem.
+package stemmer
+
+import (
+	"strings"
+)
+
+// StemOptions configures the optional behaviour available through StemWith.
+// The zero value matches Stem's behaviour exactly
+type StemOptions struct {
+	// BritishEnglish rewrites a trailing -ise/-ised/-ises/-ising/-isation/-iser
+	// to its -ize equivalent before stemming, so British and American
+	// spellings of the same word produce the same stem
+	BritishEnglish bool
+}
+
+// britishIseRules maps each member of the -ise/-yse suffix family to its
+// -ize/-yze equivalent. Rules are tried longest suffix first so e.g.
+// "isation" is rewritten as a whole rather than being caught by the bare
+// "ise" rule. The -yse forms (analyse/analyze, paralyse/paralyze) follow the
+// same British/American alternation as -ise/-ize, so they're covered by the
+// same table rather than a separate pass. base is the bare suffix ("ise" or
+// "yse") used to reconstruct the dictionary word for an exception lookup
+var britishIseRules = []struct {
+	suffix  string
+	replace string
+	base    string
+}{
+	{"isation", "ization", "ise"},
+	{"ysation", "yzation", "yse"},
+	{"ising", "izing", "ise"},
+	{"ysing", "yzing", "yse"},
+	{"ised", "ized", "ise"},
+	{"iser", "izer", "ise"},
+	{"ises", "izes", "ise"},
+	{"ysed", "yzed", "yse"},
+	{"yser", "yzer", "yse"},
+	{"yses", "yzes", "yse"},
+	{"ise", "ize", "ise"},
+	{"yse", "yze", "yse"},
+}
+
+// britishIseExceptions are -ise words that are not British spellings of an
+// -ize word and so must be left alone
+var britishIseExceptions = map[string]bool{
+	"surprise":    true,
+	"advertise":   true,
+	"comprise":    true,
+	"compromise":  true,
+	"demise":      true,
+	"despise":     true,
+	"devise":      true,
+	"disguise":    true,
+	"enterprise":  true,
+	"excise":      true,
+	"exercise":    true,
+	"franchise":   true,
+	"improvise":   true,
+	"merchandise": true,
+	"revise":      true,
+	"supervise":   true,
+	"surmise":     true,
+	"televise":    true,
+}
+
+// StemWith runs Stem with opts applied. With the zero value StemOptions it
+// behaves exactly like Stem
+func StemWith(word string, opts StemOptions) string {
+	if opts.BritishEnglish {
+		word = normaliseBritishIse(strings.ToLower(strings.TrimSpace(word)))
+	}
+
+	return Stem(word)
+}
+
+// normaliseBritishIse rewrites the longest matching member of the -ise suffix
+// family to its -ize equivalent, unless doing so would affect one of the
+// exception words that merely happens to end in -ise
+func normaliseBritishIse(word string) string {
+	for _, rule := range britishIseRules {
+		if !hasSuffix(word, rule.suffix) {
+			continue
+		}
+
+		stem := word[:len(word)-len(rule.suffix)]
+		if britishIseExceptions[stem+rule.base] {
+			return word
+		}
+
+		return stem + rule.replace
+	}
+
+	return word
+}
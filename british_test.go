@@ -0,0 +1,58 @@
+/*
+ *  British/American -ise/-ize normalisation for go-stemmer (Test)
+ *  Developed by Suhail Patel <me@suhailpatel.com>
+ */
+package stemmer
+
+import "testing"
+
+func TestStemWithBritishEnglish(t *testing.T) {
+	pairs := [][2]string{
+		{"organise", "organize"},
+		{"organisation", "organization"},
+		{"realisation", "realization"},
+		{"analyser", "analyzer"},
+	}
+
+	for _, pair := range pairs {
+		british, american := pair[0], pair[1]
+
+		got := StemWith(british, StemOptions{BritishEnglish: true})
+		want := StemWith(american, StemOptions{BritishEnglish: true})
+
+		if got != want {
+			t.Errorf("Expected %s and %s to stem to the same value, got %s and %s", british, american, got, want)
+		}
+	}
+}
+
+func TestStemWithBritishEnglishExceptions(t *testing.T) {
+	exceptions := []string{"surprise", "exercise", "despise"}
+
+	for _, word := range exceptions {
+		got := StemWith(word, StemOptions{BritishEnglish: true})
+		want := Stem(word)
+
+		if got != want {
+			t.Errorf("Expected exception word %s to be left untouched, got %s want %s", word, got, want)
+		}
+	}
+}
+
+// TestNormaliseBritishIseExceptionOnYseSuffix is a regression test for the
+// exception lookup rebuilding the dictionary word with the rule's own base
+// suffix rather than a hardcoded "ise": without it, a -yse exception could
+// never match since e.g. "paralysed" would be looked up as "paralise"
+// rather than "paralyse"
+func TestNormaliseBritishIseExceptionOnYseSuffix(t *testing.T) {
+	original := britishIseExceptions
+	britishIseExceptions = map[string]bool{"paralyse": true}
+	defer func() { britishIseExceptions = original }()
+
+	forms := []string{"paralyse", "paralysed", "paralysing", "paralyser"}
+	for _, word := range forms {
+		if got := normaliseBritishIse(word); got != word {
+			t.Errorf("Expected exception word %s to be left untouched, got %s", word, got)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+// FSM suffix matcher for go-stemmer
+// Developed by Suhail Patel <me@suhailpatel.com>
+//
+// Copyright (C) 2013 Suhail Patel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this
+// software and associated documentation files (the "Software"), to deal in the Software
+// without restriction, including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons
+// to whom the Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or
+// substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+// PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR
+// ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Steps 1a, 2, 3 and 4 of the original Porter algorithm (see stemmer.go) each
+// pick the single longest matching suffix out of a fixed list and replace it.
+// Rather than test each suffix in turn with checkReplace, a suffixFSM builds a
+// trie of the reversed suffixes once at package init and walks the word from
+// its last byte backwards, which lets the longest match be found with one
+// pass over the word instead of one strings.HasSuffix call per rule.
+package stemmer
+
+// suffixRule is a single suffix -> replacement mapping, gated by an optional
+// condition evaluated against the stem left behind once the suffix is removed
+type suffixRule struct {
+	suffix    string
+	replace   string
+	condition stemCondition
+}
+
+// noTransition marks the absence of an outgoing edge for a byte in the FSM's
+// transition table
+const noTransition = -1
+
+// noRule marks a state as not being an accept state for any rule
+const noRule = -1
+
+// suffixFSM is a trie of reversed suffixes flattened into a transition table,
+// built once by newSuffixFSM and then reused on every call to apply
+type suffixFSM struct {
+	trans  [][256]int16
+	accept []int16
+	rules  []suffixRule
+}
+
+// newSuffixFSM builds a suffixFSM out of rules, inserting each rule's suffix
+// into the trie in reverse (so the FSM can be walked from the end of a word)
+func newSuffixFSM(rules []suffixRule) *suffixFSM {
+	fsm := &suffixFSM{rules: rules}
+	fsm.addState()
+
+	for ruleIdx, rule := range rules {
+		state := int16(0)
+
+		for i := len(rule.suffix) - 1; i >= 0; i-- {
+			ch := rule.suffix[i]
+			next := fsm.trans[state][ch]
+			if next == noTransition {
+				next = fsm.addState()
+				fsm.trans[state][ch] = next
+			}
+			state = next
+		}
+
+		fsm.accept[state] = int16(ruleIdx)
+	}
+
+	return fsm
+}
+
+// addState appends a fresh, unconnected state to the FSM and returns its index
+func (f *suffixFSM) addState() int16 {
+	var trans [256]int16
+	for i := range trans {
+		trans[i] = noTransition
+	}
+
+	f.trans = append(f.trans, trans)
+	f.accept = append(f.accept, noRule)
+
+	return int16(len(f.trans) - 1)
+}
+
+// apply walks word from its last byte towards the front following the FSM's
+// transitions, keeping track of the longest matching rule seen along the way.
+// If that rule's condition (when present) rejects the stem, word is returned
+// unchanged, matching checkReplace's behaviour of not trying any further
+// (shorter) rule once a suffix has matched
+func (f *suffixFSM) apply(word string) string {
+	state := int16(0)
+	matchedRule := int16(noRule)
+	matchedLen := 0
+
+	for i, consumed := len(word)-1, 0; i >= 0; i, consumed = i-1, consumed+1 {
+		next := f.trans[state][word[i]]
+		if next == noTransition {
+			break
+		}
+
+		state = next
+		if f.accept[state] != noRule {
+			matchedRule = f.accept[state]
+			matchedLen = consumed + 1
+		}
+	}
+
+	if matchedRule == noRule {
+		return word
+	}
+
+	rule := f.rules[matchedRule]
+	stem := word[:len(word)-matchedLen]
+	if rule.condition != nil && !rule.condition(stem) {
+		return word
+	}
+
+	return stem + rule.replace
+}
@@ -0,0 +1,189 @@
+// Pipeline for go-stemmer
+// Developed by Suhail Patel <me@suhailpatel.com>
+//
+// Copyright (C) 2013 Suhail Patel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this
+// software and associated documentation files (the "Software"), to deal in the Software
+// without restriction, including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons
+// to whom the Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or
+// substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+// PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR
+// ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Pipeline turns the package from a single Stem function into a small, ordered
+// set of stages (tokenize, lowercase, drop stopwords, apply overrides, stem)
+// that can be run over a whole piece of text, in the spirit of elasticlunr's
+// Pipeline/PipelineFn. It is intended as a drop-in indexing front-end; Stem
+// itself is untouched and remains the one-word entry point.
+package stemmer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// PipelineFn transforms a single token, returning the empty string to drop the
+// token from the pipeline's output entirely. Register one with AddStage to
+// run it after the built-in stopword/override/stem stages
+type PipelineFn func(token string) string
+
+// Pipeline holds the ordered stages that Run and RunToken apply to text. Use
+// NewPipeline to construct one with the package defaults
+type Pipeline struct {
+	tokenizer    func(text string) []string
+	stopwords    map[string]bool
+	useStopwords bool
+	overrides    map[string]string
+	stages       []PipelineFn
+}
+
+// NewPipeline returns a Pipeline configured with the default tokenizer, the
+// default English stopword list enabled, and no overrides
+func NewPipeline() *Pipeline {
+	return &Pipeline{
+		tokenizer:    defaultTokenizer,
+		stopwords:    defaultStopwords,
+		useStopwords: true,
+		overrides:    map[string]string{},
+	}
+}
+
+// SetTokenizer replaces the pipeline's tokenizer, which splits a block of text
+// into the tokens that are then run through the remaining stages
+func (p *Pipeline) SetTokenizer(tokenizer func(text string) []string) *Pipeline {
+	p.tokenizer = tokenizer
+	return p
+}
+
+// SetStopwords replaces the pipeline's stopword list with words
+func (p *Pipeline) SetStopwords(words []string) *Pipeline {
+	stopwords := make(map[string]bool, len(words))
+	for _, word := range words {
+		stopwords[strings.ToLower(word)] = true
+	}
+	p.stopwords = stopwords
+	return p
+}
+
+// DisableStopwords turns off stopword filtering entirely, so every token
+// (other than ones dropped by an override) is stemmed and returned
+func (p *Pipeline) DisableStopwords() *Pipeline {
+	p.useStopwords = false
+	return p
+}
+
+// AddOverride pins word to stem, bypassing the stemming algorithm for that word.
+// This lets callers correct cases the algorithm gets wrong (or enforce
+// domain-specific stems) without having to fork the package
+func (p *Pipeline) AddOverride(word, stem string) *Pipeline {
+	p.overrides[strings.ToLower(word)] = stem
+	return p
+}
+
+// AddStage appends a custom PipelineFn, run in registration order after the
+// built-in stopword/override/stem stages. This lets callers fold in their own
+// token transforms (e.g. synonym expansion, domain-specific normalisation)
+// without forking RunToken; returning "" from a stage drops the token
+func (p *Pipeline) AddStage(fn PipelineFn) *Pipeline {
+	p.stages = append(p.stages, fn)
+	return p
+}
+
+// Run tokenizes text and runs every resulting token through RunToken,
+// returning the stemmed tokens in order. Tokens dropped by the stopword stage
+// are omitted from the result
+func (p *Pipeline) Run(text string) []string {
+	tokens := p.tokenizer(text)
+	stemmed := make([]string, 0, len(tokens))
+
+	for _, token := range tokens {
+		if out := p.RunToken(token); out != "" {
+			stemmed = append(stemmed, out)
+		}
+	}
+
+	return stemmed
+}
+
+// RunToken runs the transforming stages (lowercase, stopword filter, override
+// lookup, stem, then any stages registered with AddStage) against a single
+// token, returning "" if the token is dropped by the stopword stage or by a
+// custom stage
+func (p *Pipeline) RunToken(token string) string {
+	token = strings.ToLower(strings.TrimSpace(token))
+	if token == "" {
+		return ""
+	}
+
+	if p.useStopwords && p.stopwords[token] {
+		return ""
+	}
+
+	if stem, ok := p.overrides[token]; ok {
+		token = stem
+	} else {
+		token = Stem(token)
+	}
+
+	for _, stage := range p.stages {
+		token = stage(token)
+		if token == "" {
+			return ""
+		}
+	}
+
+	return token
+}
+
+// defaultTokenizer splits text on whitespace and Unicode punctuation, except
+// for the apostrophe, which is left alone so contractions like "don't" and
+// "it's" survive as single tokens and can be matched against
+// defaultStopwords, which lists them in that form
+func defaultTokenizer(text string) []string {
+	return strings.FieldsFunc(text, func(r rune) bool {
+		return unicode.IsSpace(r) || (unicode.IsPunct(r) && r != '\'')
+	})
+}
+
+// defaultStopwords is the default English stopword list used by NewPipeline
+var defaultStopwords = newStopwordSet(
+	"a", "about", "above", "after", "again", "against", "all", "am", "an",
+	"and", "any", "are", "aren't", "as", "at", "be", "because", "been",
+	"before", "being", "below", "between", "both", "but", "by", "can't",
+	"cannot", "could", "couldn't", "did", "didn't", "do", "does", "doesn't",
+	"doing", "don't", "down", "during", "each", "few", "for", "from",
+	"further", "had", "hadn't", "has", "hasn't", "have", "haven't", "having",
+	"he", "he'd", "he'll", "he's", "her", "here", "here's", "hers", "herself",
+	"him", "himself", "his", "how", "how's", "i", "i'd", "i'll", "i'm",
+	"i've", "if", "in", "into", "is", "isn't", "it", "it's", "its", "itself",
+	"let's", "me", "more", "most", "mustn't", "my", "myself", "no", "nor",
+	"not", "of", "off", "on", "once", "only", "or", "other", "ought", "our",
+	"ours", "ourselves", "out", "over", "own", "same", "shan't", "she",
+	"she'd", "she'll", "she's", "should", "shouldn't", "so", "some", "such",
+	"than", "that", "that's", "the", "their", "theirs", "them", "themselves",
+	"then", "there", "there's", "these", "they", "they'd", "they'll",
+	"they're", "they've", "this", "those", "through", "to", "too", "under",
+	"until", "up", "very", "was", "wasn't", "we", "we'd", "we'll", "we're",
+	"we've", "were", "weren't", "what", "what's", "when", "when's", "where",
+	"where's", "which", "while", "who", "who's", "whom", "why", "why's",
+	"with", "won't", "would", "wouldn't", "you", "you'd", "you'll", "you're",
+	"you've", "your", "yours", "yourself", "yourselves",
+)
+
+// newStopwordSet turns a list of words into a lookup set
+func newStopwordSet(words ...string) map[string]bool {
+	set := make(map[string]bool, len(words))
+	for _, word := range words {
+		set[word] = true
+	}
+	return set
+}
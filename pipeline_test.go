@@ -0,0 +1,77 @@
+/*
+ *  Pipeline for go-stemmer (Test)
+ *  Developed by Suhail Patel <me@suhailpatel.com>
+ */
+package stemmer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPipelineRun(t *testing.T) {
+	p := NewPipeline()
+
+	got := p.Run("The runners were quickly running through the fields")
+	want := []string{"runner", "quickli", "run", "field"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v but got %v", want, got)
+	}
+}
+
+func TestPipelineOverride(t *testing.T) {
+	p := NewPipeline().AddOverride("running", "run")
+
+	if got := p.RunToken("running"); got != "run" {
+		t.Errorf("Expected override to return run but got %s", got)
+	}
+}
+
+func TestPipelineAddStage(t *testing.T) {
+	p := NewPipeline().AddStage(func(token string) string {
+		return token + "!"
+	})
+
+	if got := p.RunToken("running"); got != "run!" {
+		t.Errorf("Expected stage to run after stemming but got %s", got)
+	}
+}
+
+func TestPipelineAddStageDropsToken(t *testing.T) {
+	p := NewPipeline().AddStage(func(token string) string {
+		if token == "run" {
+			return ""
+		}
+		return token
+	})
+
+	got := p.Run("quickly running")
+	want := []string{"quickli"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v but got %v", want, got)
+	}
+}
+
+func TestPipelineRunContractions(t *testing.T) {
+	p := NewPipeline()
+
+	got := p.Run("I don't think we aren't going")
+	want := []string{"think", "go"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v but got %v", want, got)
+	}
+}
+
+func TestPipelineDisableStopwords(t *testing.T) {
+	p := NewPipeline().DisableStopwords()
+
+	got := p.Run("the quick brown fox")
+	want := []string{"the", "quick", "brown", "fox"}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v but got %v", want, got)
+	}
+}
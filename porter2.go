@@ -0,0 +1,501 @@
+// Porter2 (English Snowball) Stemmer Algorithm in Go
+// Developed by Suhail Patel <me@suhailpatel.com>
+//
+// Copyright (C) 2013 Suhail Patel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this
+// software and associated documentation files (the "Software"), to deal in the Software
+// without restriction, including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons
+// to whom the Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or
+// substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+// PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR
+// ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// This is an implementation of the Porter2 (English Snowball) Stemmer algorithm by
+// Martin Porter. It is a substantial revision of the original Porter algorithm
+// (see stemmer.go) which replaces the m() consonant-sequence measure with two
+// regions, R1 and R2, and adds a preprocessing pass plus a list of exceptional
+// and invariant forms.
+//
+// See http://snowball.tartarus.org/algorithms/english/stemmer.html for more
+// information about the algorithm
+package stemmer
+
+import (
+	"strings"
+)
+
+// exceptionalStems are irregular forms that are returned immediately, before any
+// of the steps below are run, as defined by the algorithm
+var exceptionalStems = map[string]string{
+	"skis":   "ski",
+	"skies":  "sky",
+	"dying":  "die",
+	"lying":  "lie",
+	"tying":  "tie",
+	"idly":   "idli",
+	"gently": "gentl",
+	"ugly":   "ugli",
+	"early":  "earli",
+	"only":   "onli",
+	"singly": "singl",
+}
+
+// invariantStems are left untouched by every step of the algorithm, either because
+// they are given as such by the algorithm (sky, news, howe, atlas, cosmos, bias,
+// andes) or because stemming them would produce the wrong result (inning, outing,
+// canning, herring, earring and the -ceed verbs, which would otherwise be mangled
+// by the plural and -ing suffix rules)
+var invariantStems = map[string]bool{
+	"sky": true, "news": true, "howe": true,
+	"atlas": true, "cosmos": true, "bias": true, "andes": true,
+	"inning": true, "outing": true, "canning": true, "herring": true, "earring": true,
+	"proceed": true, "exceed": true, "succeed": true,
+}
+
+// validLiPreceders is the set of letters that may precede a stripped "li" suffix
+// in step 2
+const validLiPreceders = "cdeghkmnrt"
+
+// Stem2 takes in a word and runs through the various steps of the Porter2
+// (English Snowball) algorithm. Like Stem, words of length 2 or less are
+// returned unchanged. Words are converted to lower case and will be returned
+// in lower case.
+func Stem2(word string) string {
+	word = strings.ToLower(strings.TrimSpace(word))
+
+	if len(word) <= 2 {
+		return word
+	}
+
+	if stem, ok := exceptionalStems[word]; ok {
+		return stem
+	}
+
+	if invariantStems[word] {
+		return word
+	}
+
+	word = preprocess(word)
+	r1, r2 := regions(word)
+
+	word, r1, r2 = step0Porter2(word, r1, r2)
+	word, r1, r2 = step1aPorter2(word, r1, r2)
+	word, r1, r2 = step1bPorter2(word, r1, r2)
+	word, r1, r2 = step1cPorter2(word, r1, r2)
+	word, r1, r2 = step2Porter2(word, r1, r2)
+	word, r1, r2 = step3Porter2(word, r1, r2)
+	word, r1, r2 = step4Porter2(word, r1, r2)
+	word, _, _ = step5Porter2(word, r1, r2)
+
+	return strings.Replace(word, "Y", "y", -1)
+}
+
+// preprocess strips a leading apostrophe and marks any "y" that acts as a
+// consonant (one at the very start of the word, or one immediately following
+// a vowel) as "Y" so the rest of the algorithm can treat vowel-y and
+// consonant-y differently without threading extra state through every step
+func preprocess(word string) string {
+	if strings.HasPrefix(word, "'") {
+		word = word[1:]
+	}
+
+	if len(word) == 0 {
+		return word
+	}
+
+	b := []byte(word)
+	if b[0] == 'y' {
+		b[0] = 'Y'
+	}
+
+	for i := 1; i < len(b); i++ {
+		if b[i] == 'y' && isVowel(b[i-1]) {
+			b[i] = 'Y'
+		}
+	}
+
+	return string(b)
+}
+
+// isVowel determines whether ch is a vowel under the Porter2 model, where a is
+// consonantal "y" (marked "Y" by preprocess) is not a vowel but a plain "y" is
+func isVowel(ch byte) bool {
+	switch ch {
+	case 'a', 'e', 'i', 'o', 'u', 'y':
+		return true
+	}
+	return false
+}
+
+// regions computes the R1 and R2 regions used throughout the algorithm. R1 is
+// the region after the first non-vowel following a vowel, and R2 is the same
+// region computed again within R1. Words starting with the prefixes gener,
+// commun or arsen are special-cased to start R1 immediately after the prefix
+func regions(word string) (int, int) {
+	var r1 int
+
+	switch {
+	case strings.HasPrefix(word, "gener"):
+		r1 = 5
+	case strings.HasPrefix(word, "commun"):
+		r1 = 6
+	case strings.HasPrefix(word, "arsen"):
+		r1 = 5
+	default:
+		r1 = firstRegionAfter(word, 0)
+	}
+
+	r2 := firstRegionAfter(word, r1)
+
+	return r1, r2
+}
+
+// firstRegionAfter finds the offset right after the first non-vowel that
+// follows a vowel, scanning word from start onwards
+func firstRegionAfter(word string, start int) int {
+	i := start
+	for i < len(word) && !isVowel(word[i]) {
+		i++
+	}
+	for i < len(word) && isVowel(word[i]) {
+		i++
+	}
+
+	if i < len(word) {
+		return i + 1
+	}
+	return len(word)
+}
+
+// hasVowelInStem reports whether stem contains a vowel anywhere, used by the
+// step 1a/1b suffix conditions
+func hasVowelInStem(stem string) bool {
+	for i := 0; i < len(stem); i++ {
+		if isVowel(stem[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasDoubleConsonantSuffix2 mirrors hasDoubleConsonantSuffix but uses the
+// Porter2 vowel/consonant model, where a consonantal "y" has already been
+// marked "Y" by preprocess
+func hasDoubleConsonantSuffix2(word string) bool {
+	n := len(word)
+	if n < 2 {
+		return false
+	}
+
+	return word[n-1] == word[n-2] && !isVowel(word[n-1])
+}
+
+// shortSyllable reports whether the end of word forms a "short syllable": a
+// vowel followed by a non-vowel other than w, x or Y and preceded by a
+// non-vowel, or a vowel at the very start of the word followed by a non-vowel
+func shortSyllable(word string) bool {
+	n := len(word)
+	if n < 2 {
+		return false
+	}
+
+	last := word[n-1]
+	if isVowel(last) || !isVowel(word[n-2]) {
+		return false
+	}
+
+	if n == 2 {
+		return true
+	}
+
+	if last == 'w' || last == 'x' || last == 'Y' {
+		return false
+	}
+
+	return !isVowel(word[n-3])
+}
+
+// isShortWord reports whether word is "short" as defined by the algorithm: R1
+// is null (empty) and the word ends in a short syllable
+func isShortWord(word string, r1 int) bool {
+	return r1 >= len(word) && shortSyllable(word)
+}
+
+// Step 0 removes the longest of the apostrophe suffixes "'s'", "'s" and "'"
+func step0Porter2(word string, r1, r2 int) (string, int, int) {
+	matched := false
+
+	word, matched = checkReplace(word, "'s'", "", matched, nil)
+	word, matched = checkReplace(word, "'s", "", matched, nil)
+	word, matched = checkReplace(word, "'", "", matched, nil)
+
+	return word, r1, r2
+}
+
+// Step 1a deals with plurals, same as the original algorithm but with
+// slightly different suffix handling around "ied"/"ies"
+func step1aPorter2(word string, r1, r2 int) (string, int, int) {
+	matched := false
+
+	word, matched = checkReplace(word, "sses", "ss", matched, nil)
+
+	if !matched {
+		for _, suffix := range []string{"ied", "ies"} {
+			if !hasSuffix(word, suffix) {
+				continue
+			}
+
+			stem := word[:len(word)-len(suffix)]
+			if len(stem) > 1 {
+				word = stem + "i"
+			} else {
+				word = stem + "ie"
+			}
+			matched = true
+			break
+		}
+	}
+
+	if matched || hasSuffix(word, "us") || hasSuffix(word, "ss") {
+		return word, r1, r2
+	}
+
+	word, _ = checkReplace(word, "s", "", false, func(stem string) bool {
+		return len(stem) >= 2 && hasVowelInStem(stem[:len(stem)-1])
+	})
+
+	return word, r1, r2
+}
+
+// Step 1b removes -eed/-eedly/-ed/-edly/-ing/-ingly suffixes and cleans up the
+// stem left behind, same intent as the original algorithm's step1b but using
+// the R1 region instead of the m() measure
+func step1bPorter2(word string, r1, r2 int) (string, int, int) {
+	matched := false
+	inR1 := func(stem string) bool {
+		return len(stem) >= r1
+	}
+
+	word, matched = checkReplace(word, "eedly", "ee", matched, inR1)
+	word, matched = checkReplace(word, "eed", "ee", matched, inR1)
+
+	if matched {
+		return word, r1, r2
+	}
+
+	prevWord := word
+	word, matched = checkReplace(word, "ingly", "", matched, hasVowelInStem)
+	word, matched = checkReplace(word, "edly", "", matched, hasVowelInStem)
+	word, matched = checkReplace(word, "ing", "", matched, hasVowelInStem)
+	word, matched = checkReplace(word, "ed", "", matched, hasVowelInStem)
+
+	if matched && word != prevWord {
+		switch {
+		case hasSuffix(word, "at"), hasSuffix(word, "bl"), hasSuffix(word, "iz"):
+			word += "e"
+		case hasDoubleConsonantSuffix2(word) && word[len(word)-1] != 'l' &&
+			word[len(word)-1] != 's' && word[len(word)-1] != 'z':
+			word = word[:len(word)-1]
+		case isShortWord(word, r1):
+			word += "e"
+		}
+	}
+
+	return word, r1, r2
+}
+
+// Step 1c replaces a trailing y or Y with i, provided it is preceded by a
+// non-vowel that is not itself the first letter of the word
+func step1cPorter2(word string, r1, r2 int) (string, int, int) {
+	n := len(word)
+	if n < 3 {
+		return word, r1, r2
+	}
+
+	last := word[n-1]
+	if last != 'y' && last != 'Y' {
+		return word, r1, r2
+	}
+
+	if !isVowel(word[n-2]) {
+		word = word[:n-1] + "i"
+	}
+
+	return word, r1, r2
+}
+
+// Step 2 maps a large set of derivational suffixes down to shorter forms,
+// switching on the penultimate letter of the word for a speed boost, same
+// approach as the original algorithm's step2
+func step2Porter2(word string, r1, r2 int) (string, int, int) {
+	matched := false
+	inR1 := func(stem string) bool {
+		return len(stem) >= r1
+	}
+
+	if len(word) < 2 {
+		return word, r1, r2
+	}
+
+	switch word[len(word)-2] {
+	case 'a':
+		word, matched = checkReplace(word, "ational", "ate", matched, inR1)
+		word, matched = checkReplace(word, "tional", "tion", matched, inR1)
+	case 'c':
+		word, matched = checkReplace(word, "enci", "ence", matched, inR1)
+		word, matched = checkReplace(word, "anci", "ance", matched, inR1)
+	case 'e':
+		word, matched = checkReplace(word, "izer", "ize", matched, inR1)
+	case 'g':
+		word, matched = checkReplace(word, "ogi", "og", matched, func(stem string) bool {
+			return len(stem) >= r1 && len(stem) > 0 && stem[len(stem)-1] == 'l'
+		})
+	case 'l':
+		word, matched = checkReplace(word, "lessli", "less", matched, inR1)
+		word, matched = checkReplace(word, "entli", "ent", matched, inR1)
+		word, matched = checkReplace(word, "fulli", "ful", matched, inR1)
+		word, matched = checkReplace(word, "ousli", "ous", matched, inR1)
+		word, matched = checkReplace(word, "abli", "able", matched, inR1)
+		word, matched = checkReplace(word, "alli", "al", matched, inR1)
+		word, matched = checkReplace(word, "bli", "ble", matched, inR1)
+		word, matched = checkReplace(word, "li", "", matched, func(stem string) bool {
+			return len(stem) >= r1 && len(stem) > 0 && strings.IndexByte(validLiPreceders, stem[len(stem)-1]) >= 0
+		})
+	case 'o':
+		word, matched = checkReplace(word, "ization", "ize", matched, inR1)
+		word, matched = checkReplace(word, "ation", "ate", matched, inR1)
+		word, matched = checkReplace(word, "ator", "ate", matched, inR1)
+	case 's':
+		word, matched = checkReplace(word, "fulness", "ful", matched, inR1)
+		word, matched = checkReplace(word, "ousness", "ous", matched, inR1)
+		word, matched = checkReplace(word, "iveness", "ive", matched, inR1)
+		word, matched = checkReplace(word, "alism", "al", matched, inR1)
+	case 't':
+		word, matched = checkReplace(word, "biliti", "ble", matched, inR1)
+		word, matched = checkReplace(word, "iviti", "ive", matched, inR1)
+		word, matched = checkReplace(word, "aliti", "al", matched, inR1)
+	}
+
+	return word, r1, r2
+}
+
+// Step 3 deals with a further set of suffixes, again switching on the
+// penultimate letter of the word
+func step3Porter2(word string, r1, r2 int) (string, int, int) {
+	matched := false
+	inR1 := func(stem string) bool {
+		return len(stem) >= r1
+	}
+	inR2 := func(stem string) bool {
+		return len(stem) >= r2
+	}
+
+	if len(word) < 2 {
+		return word, r1, r2
+	}
+
+	switch word[len(word)-2] {
+	case 'a':
+		word, matched = checkReplace(word, "ational", "ate", matched, inR1)
+		word, matched = checkReplace(word, "tional", "tion", matched, inR1)
+		word, matched = checkReplace(word, "ical", "ic", matched, inR1)
+	case 't':
+		word, matched = checkReplace(word, "icate", "ic", matched, inR1)
+		word, matched = checkReplace(word, "iciti", "ic", matched, inR1)
+	case 'u':
+		word, matched = checkReplace(word, "ful", "", matched, inR1)
+	case 's':
+		word, matched = checkReplace(word, "ness", "", matched, inR1)
+	case 'v':
+		word, matched = checkReplace(word, "ative", "", matched, inR2)
+	case 'z':
+		word, matched = checkReplace(word, "alize", "al", matched, inR1)
+	}
+
+	return word, r1, r2
+}
+
+// Step 4 removes a final set of suffixes when they lie in R2, mirroring the
+// original algorithm's step4 but without its archaic "ou" rule
+func step4Porter2(word string, r1, r2 int) (string, int, int) {
+	matched := false
+	inR2 := func(stem string) bool {
+		return len(stem) >= r2
+	}
+
+	if len(word) < 2 {
+		return word, r1, r2
+	}
+
+	switch word[len(word)-2] {
+	case 'a':
+		word, matched = checkReplace(word, "al", "", matched, inR2)
+	case 'c':
+		word, matched = checkReplace(word, "ance", "", matched, inR2)
+		word, matched = checkReplace(word, "ence", "", matched, inR2)
+	case 'e':
+		word, matched = checkReplace(word, "er", "", matched, inR2)
+	case 'i':
+		word, matched = checkReplace(word, "ic", "", matched, inR2)
+	case 'l':
+		word, matched = checkReplace(word, "able", "", matched, inR2)
+		word, matched = checkReplace(word, "ible", "", matched, inR2)
+	case 'n':
+		word, matched = checkReplace(word, "ement", "", matched, inR2)
+		word, matched = checkReplace(word, "ment", "", matched, inR2)
+		word, matched = checkReplace(word, "ant", "", matched, inR2)
+		word, matched = checkReplace(word, "ent", "", matched, inR2)
+	case 'o':
+		word, matched = checkReplace(word, "ion", "", matched, func(stem string) bool {
+			return len(stem) >= r2 && len(stem) > 0 && (stem[len(stem)-1] == 's' || stem[len(stem)-1] == 't')
+		})
+	case 's':
+		word, matched = checkReplace(word, "ism", "", matched, inR2)
+	case 't':
+		word, matched = checkReplace(word, "ate", "", matched, inR2)
+		word, matched = checkReplace(word, "iti", "", matched, inR2)
+	case 'u':
+		word, matched = checkReplace(word, "ous", "", matched, inR2)
+	case 'v':
+		word, matched = checkReplace(word, "ive", "", matched, inR2)
+	case 'z':
+		word, matched = checkReplace(word, "ize", "", matched, inR2)
+	}
+
+	return word, r1, r2
+}
+
+// Step 5 removes a final e (if in R2, or in R1 and not preceded by a short
+// syllable) and a final l (if in R2 and preceded by another l)
+func step5Porter2(word string, r1, r2 int) (string, int, int) {
+	n := len(word)
+	if n == 0 {
+		return word, r1, r2
+	}
+
+	switch word[n-1] {
+	case 'e':
+		stem := word[:n-1]
+		if len(stem) >= r2 || (len(stem) >= r1 && !shortSyllable(stem)) {
+			word = stem
+		}
+	case 'l':
+		if n >= 2 && word[n-2] == 'l' {
+			stem := word[:n-1]
+			if len(stem) >= r2 {
+				word = stem
+			}
+		}
+	}
+
+	return word, r1, r2
+}
@@ -0,0 +1,69 @@
+/*
+ *  Porter2 (English Snowball) Stemmer Algorithm in Go (Test)
+ *  Developed by Suhail Patel <me@suhailpatel.com>
+ */
+package stemmer
+
+import (
+    "testing"
+    "bufio"
+    "os"
+    "strings"
+)
+
+// The official Snowball site publishes a voc.txt/output.txt pair covering the
+// Porter2 algorithm, in the same spirit as the diffs.txt pair used by
+// TestCorpus for the original Porter stemmer
+func TestCorpus2(t *testing.T) {
+	input, errIn := os.Open("corpus/test_input2.txt")
+    output, errOut := os.Open("corpus/test_output2.txt")
+
+    defer input.Close()
+    defer output.Close()
+
+    if errIn != nil || errOut != nil {
+        t.Fatalf("Could not read input or output test files [%s, %s]", errIn, errOut)
+    }
+
+    inScan := bufio.NewScanner(input)
+    outScan := bufio.NewScanner(output)
+
+    for inScan.Scan() && outScan.Scan() {
+        in := inScan.Text()
+        out := outScan.Text()
+        stemmed := Stem2(in)
+
+        t.Logf("[PASS] Input: %s → Expected: %s, Stemmed: %s\n", in, out, stemmed)
+
+        if (!strings.EqualFold(out, stemmed)) {
+            t.Errorf("[FAIL] Expected %s but got %s for input %s\n", out, stemmed, in)
+        }
+    }
+
+    if inScan.Err() != nil || outScan.Err() != nil {
+        t.Fatalf("Could not open scanner for input or output test files [%s, %s]", inScan.Err(), outScan.Err())
+    }
+}
+
+// TestStem2IedIesShortStem exercises step1aPorter2's "ied"/"ies" handling
+// directly, independent of the corpus files above: when the stem left behind
+// is a single letter the suffix is replaced with "ie" rather than "i" (so
+// ties -> tie, cries -> cri, died -> die), the exact example given by the
+// algorithm's own spec
+func TestStem2IedIesShortStem(t *testing.T) {
+    cases := map[string]string{
+        "ties":  "tie",
+        "dies":  "die",
+        "pies":  "pie",
+        "died":  "die",
+        "tied":  "tie",
+        "cries": "cri",
+        "tries": "tri",
+    }
+
+    for word, want := range cases {
+        if got := Stem2(word); got != want {
+            t.Errorf("Expected Stem2(%s) to be %s, got %s", word, want, got)
+        }
+    }
+}
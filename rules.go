@@ -0,0 +1,202 @@
+// Exported rule tables for go-stemmer
+// Developed by Suhail Patel <me@suhailpatel.com>
+//
+// Copyright (C) 2013 Suhail Patel
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy of this
+// software and associated documentation files (the "Software"), to deal in the Software
+// without restriction, including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software, and to permit persons
+// to whom the Software is furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all copies or
+// substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR IMPLIED,
+// INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY, FITNESS FOR A PARTICULAR
+// PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR
+// ANY CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE,
+// ARISING FROM, OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN
+// THE SOFTWARE.
+
+// Steps 2, 3 and 4 of the original Porter algorithm are each just a fixed
+// family of suffix rules. This file exposes those families as data (Rule,
+// RuleSet) rather than hard-coding them as FSM literals in stemmer.go, so
+// callers that want to tweak an individual mapping, or add domain-specific
+// suffixes of their own, can do so through StemWithRules without forking the
+// package.
+package stemmer
+
+import (
+	"strings"
+)
+
+// Rule is a single suffix -> replacement mapping used by step2, step3 and
+// step4. MinMeasure is the smallest m() measure the stem left behind after
+// removing Suffix must have for the rule to apply; it is 0 for rules that
+// have no such requirement. StemEndsIn, if non-empty, additionally requires
+// the stem to end in one of the given characters, as used by step4's "ion"
+// rule; leave it empty when there's no such requirement
+type Rule struct {
+	Suffix     string
+	Replace    string
+	MinMeasure int
+	StemEndsIn string
+}
+
+// RuleSet groups the suffix rules applied by step2, step3 and step4. Pass a
+// RuleSet to StemWithRules to run the algorithm against rules other than the
+// package defaults. To customise a handful of entries, copy one of the
+// package's Step2Rules/Step3Rules/Step4Rules slices into a RuleSet field
+// rather than mutating it in place: StemWithRules caches an FSM for the
+// package defaults at init and won't notice an in-place edit to them
+type RuleSet struct {
+	Step2 []Rule
+	Step3 []Rule
+	Step4 []Rule
+}
+
+// Step2Rules are the default step 2 suffix rules
+var Step2Rules = []Rule{
+	{Suffix: "ational", Replace: "ate", MinMeasure: 1},
+	{Suffix: "tional", Replace: "tion", MinMeasure: 1},
+	{Suffix: "enci", Replace: "ence", MinMeasure: 1},
+	{Suffix: "anci", Replace: "ance", MinMeasure: 1},
+	{Suffix: "izer", Replace: "ize", MinMeasure: 1},
+	{Suffix: "logi", Replace: "log", MinMeasure: 1},
+	{Suffix: "bli", Replace: "ble", MinMeasure: 1},
+	{Suffix: "alli", Replace: "al", MinMeasure: 1},
+	{Suffix: "entli", Replace: "ent", MinMeasure: 1},
+	{Suffix: "eli", Replace: "e", MinMeasure: 1},
+	{Suffix: "ousli", Replace: "ous", MinMeasure: 1},
+	{Suffix: "ization", Replace: "ize", MinMeasure: 1},
+	{Suffix: "ation", Replace: "ate", MinMeasure: 1},
+	{Suffix: "ator", Replace: "ate", MinMeasure: 1},
+	{Suffix: "alism", Replace: "al", MinMeasure: 1},
+	{Suffix: "iveness", Replace: "ive", MinMeasure: 1},
+	{Suffix: "fulness", Replace: "ful", MinMeasure: 1},
+	{Suffix: "ousness", Replace: "ous", MinMeasure: 1},
+	{Suffix: "aliti", Replace: "al", MinMeasure: 1},
+	{Suffix: "iviti", Replace: "ive", MinMeasure: 1},
+	{Suffix: "biliti", Replace: "ble", MinMeasure: 1},
+}
+
+// Step3Rules are the default step 3 suffix rules
+var Step3Rules = []Rule{
+	{Suffix: "icate", Replace: "ic", MinMeasure: 1},
+	{Suffix: "ative", Replace: "", MinMeasure: 1},
+	{Suffix: "alize", Replace: "al", MinMeasure: 1},
+	{Suffix: "iciti", Replace: "ic", MinMeasure: 1},
+	{Suffix: "ical", Replace: "ic", MinMeasure: 1},
+	{Suffix: "ful", Replace: "", MinMeasure: 1},
+	{Suffix: "ness", Replace: "", MinMeasure: 1},
+}
+
+// Step4Rules are the default step 4 suffix rules
+var Step4Rules = []Rule{
+	{Suffix: "al", Replace: "", MinMeasure: 2},
+	{Suffix: "ance", Replace: "", MinMeasure: 2},
+	{Suffix: "ence", Replace: "", MinMeasure: 2},
+	{Suffix: "er", Replace: "", MinMeasure: 2},
+	{Suffix: "ic", Replace: "", MinMeasure: 2},
+	{Suffix: "able", Replace: "", MinMeasure: 2},
+	{Suffix: "ible", Replace: "", MinMeasure: 2},
+	{Suffix: "ant", Replace: "", MinMeasure: 2},
+	{Suffix: "ement", Replace: "", MinMeasure: 2},
+	{Suffix: "ment", Replace: "", MinMeasure: 2},
+	{Suffix: "ent", Replace: "", MinMeasure: 2},
+	{Suffix: "ion", Replace: "", MinMeasure: 2, StemEndsIn: "st"},
+	{Suffix: "ou", Replace: "", MinMeasure: 2},
+	{Suffix: "ism", Replace: "", MinMeasure: 2},
+	{Suffix: "ate", Replace: "", MinMeasure: 2},
+	{Suffix: "iti", Replace: "", MinMeasure: 2},
+	{Suffix: "ous", Replace: "", MinMeasure: 2},
+	{Suffix: "ive", Replace: "", MinMeasure: 2},
+	{Suffix: "ize", Replace: "", MinMeasure: 2},
+}
+
+// DefaultRules is the RuleSet used by Stem, combining Step2Rules, Step3Rules
+// and Step4Rules
+var DefaultRules = RuleSet{
+	Step2: Step2Rules,
+	Step3: Step3Rules,
+	Step4: Step4Rules,
+}
+
+// defaultStep2FSM, defaultStep3FSM and defaultStep4FSM are built once from
+// the default rule tables so that Stem (and StemWithRules called with
+// DefaultRules) keep paying for FSM construction only at package init, not on
+// every call
+var (
+	defaultStep2FSM = newSuffixFSM(toSuffixRules(Step2Rules))
+	defaultStep3FSM = newSuffixFSM(toSuffixRules(Step3Rules))
+	defaultStep4FSM = newSuffixFSM(toSuffixRules(Step4Rules))
+)
+
+// StemWithRules runs the Porter algorithm exactly like Stem, except that
+// steps 2, 3 and 4 apply the suffix rules in rules instead of the package
+// defaults. Stem is equivalent to StemWithRules(word, DefaultRules)
+func StemWithRules(word string, rules RuleSet) string {
+	if len(word) == 1 || len(word) == 2 {
+		return word
+	}
+
+	stemmed := strings.TrimSpace(word)
+	stemmed = strings.ToLower(stemmed)
+	stemmed = step1a(stemmed)
+	stemmed = step1b(stemmed)
+	stemmed = step1c(stemmed)
+	stemmed = applyRules(stemmed, rules.Step2, Step2Rules, defaultStep2FSM)
+	stemmed = applyRules(stemmed, rules.Step3, Step3Rules, defaultStep3FSM)
+	stemmed = applyRules(stemmed, rules.Step4, Step4Rules, defaultStep4FSM)
+	stemmed = step5(stemmed)
+
+	return stemmed
+}
+
+// applyRules resolves word against rules, reusing defaultFSM when rules is
+// the package's own default table (the common case) rather than rebuilding
+// an FSM that's already sitting in a package-level var
+func applyRules(word string, rules []Rule, defaults []Rule, defaultFSM *suffixFSM) string {
+	if sameRules(rules, defaults) {
+		return defaultFSM.apply(word)
+	}
+
+	return newSuffixFSM(toSuffixRules(rules)).apply(word)
+}
+
+// sameRules reports whether a and b share the same backing array, which is
+// true when a caller passes one of the package's own rule tables through
+// unmodified
+func sameRules(a, b []Rule) bool {
+	return len(a) == len(b) && (len(a) == 0 || &a[0] == &b[0])
+}
+
+// toSuffixRules adapts the exported, data-only Rule into the suffixFSM
+// package's suffixRule, turning MinMeasure/StemEndsIn into a condition
+// closure
+func toSuffixRules(rules []Rule) []suffixRule {
+	out := make([]suffixRule, len(rules))
+
+	for i, rule := range rules {
+		rule := rule
+		out[i] = suffixRule{
+			suffix:  rule.Suffix,
+			replace: rule.Replace,
+			condition: func(stem string) bool {
+				if m(stem) < rule.MinMeasure {
+					return false
+				}
+
+				if rule.StemEndsIn != "" &&
+					(len(stem) == 0 || strings.IndexByte(rule.StemEndsIn, stem[len(stem)-1]) < 0) {
+					return false
+				}
+
+				return true
+			},
+		}
+	}
+
+	return out
+}
@@ -0,0 +1,35 @@
+/*
+ *  Exported rule tables for go-stemmer (Test)
+ *  Developed by Suhail Patel <me@suhailpatel.com>
+ */
+package stemmer
+
+import "testing"
+
+func TestStemWithRulesDefaultMatchesStem(t *testing.T) {
+	words := []string{"caresses", "relational", "triplicate", "formalize", "electricity"}
+
+	for _, word := range words {
+		got := StemWithRules(word, DefaultRules)
+		want := Stem(word)
+
+		if got != want {
+			t.Errorf("Expected StemWithRules(%s, DefaultRules) to equal Stem(%s), got %s want %s", word, word, got, want)
+		}
+	}
+}
+
+func TestStemWithRulesCustomStep2(t *testing.T) {
+	if got := Stem("whizz"); got != "whizz" {
+		t.Fatalf("Expected the default rules to leave whizz untouched, got %s", got)
+	}
+
+	rules := DefaultRules
+	rules.Step2 = []Rule{
+		{Suffix: "zz", Replace: "z", MinMeasure: 0},
+	}
+
+	if got := StemWithRules("whizz", rules); got != "whiz" {
+		t.Errorf("Expected custom step2 rule to stem whizz to whiz, got %s", got)
+	}
+}
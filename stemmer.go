@@ -43,33 +43,20 @@ import (
 // difference of the algorithm). Words are converted to lower case and will be returned in
 // lower case
 func Stem(word string) string {
-	if len(word) == 1 || len(word) == 2 {
-		return word
-	}
-
-	stemmed := strings.TrimSpace(word)
-	stemmed = strings.ToLower(stemmed)
-	stemmed = step1a(stemmed)
-	stemmed = step1b(stemmed)
-	stemmed = step1c(stemmed)
-	stemmed = step2(stemmed)
-	stemmed = step3(stemmed)
-	stemmed = step4(stemmed)
-	stemmed = step5(stemmed)
-
-	return stemmed
+	return StemWithRules(word, DefaultRules)
 }
 
+// step1aFSM matches the longest of sses/ies/ss/s, in that priority order
+var step1aFSM = newSuffixFSM([]suffixRule{
+	{suffix: "sses", replace: "ss"},
+	{suffix: "ies", replace: "i"},
+	{suffix: "ss", replace: "ss"},
+	{suffix: "s", replace: ""},
+})
+
 // Step 1A focuses on getting rid of plurals
 func step1a(word string) string {
-	matched := false
-
-	word, matched = checkReplace(word, "sses", "ss", matched, nil)
-	word, matched = checkReplace(word, "ies", "i", matched, nil)
-	word, matched = checkReplace(word, "ss", "ss", matched, nil)
-	word, matched = checkReplace(word, "s", "", matched, nil)
-
-	return word
+	return step1aFSM.apply(word)
 }
 
 // Step 1B also focuses on getting rid of plurals
@@ -121,122 +108,6 @@ func step1c(word string) string {
 	return word
 }
 
-// Step 2 is just going through the rules, switch on the penultimate letter
-// for a speed boost in comparison for which rules match/execute
-func step2(word string) string {
-	matched := false
-
-	mMoreZero := func(stem string) bool {
-		return m(stem) > 0
-	}
-
-	switch word[len(word)-2] {
-	case 'a':
-		word, matched = checkReplace(word, "ational", "ate", matched, mMoreZero)
-		word, matched = checkReplace(word, "tional", "tion", matched, mMoreZero)
-		break
-
-	case 'c':
-		word, matched = checkReplace(word, "enci", "ence", matched, mMoreZero)
-		word, matched = checkReplace(word, "anci", "ance", matched, mMoreZero)
-		break
-
-	case 'e':
-		word, matched = checkReplace(word, "izer", "ize", matched, mMoreZero)
-		break
-
-	case 'g':
-		word, matched = checkReplace(word, "logi", "log", matched, mMoreZero)
-		break
-
-	case 'l':
-		word, matched = checkReplace(word, "bli", "ble", matched, mMoreZero)
-		word, matched = checkReplace(word, "alli", "al", matched, mMoreZero)
-		word, matched = checkReplace(word, "entli", "ent", matched, mMoreZero)
-		word, matched = checkReplace(word, "eli", "e", matched, mMoreZero)
-		word, matched = checkReplace(word, "ousli", "ous", matched, mMoreZero)
-		break
-
-	case 'o':
-		word, matched = checkReplace(word, "ization", "ize", matched, mMoreZero)
-		word, matched = checkReplace(word, "ation", "ate", matched, mMoreZero)
-		word, matched = checkReplace(word, "ator", "ate", matched, mMoreZero)
-		break
-
-	case 's':
-		word, matched = checkReplace(word, "alism", "al", matched, mMoreZero)
-		word, matched = checkReplace(word, "iveness", "ive", matched, mMoreZero)
-		word, matched = checkReplace(word, "fulness", "ful", matched, mMoreZero)
-		word, matched = checkReplace(word, "ousness", "ous", matched, mMoreZero)
-		break
-
-	case 't':
-		word, matched = checkReplace(word, "aliti", "al", matched, mMoreZero)
-		word, matched = checkReplace(word, "iviti", "ive", matched, mMoreZero)
-		word, matched = checkReplace(word, "biliti", "ble", matched, mMoreZero)
-		break
-
-	default:
-		break
-	}
-
-	return word
-}
-
-// Stemming words as part of Step 3
-func step3(word string) string {
-	mMoreZero := func(stem string) bool {
-		return m(stem) > 0
-	}
-
-	matched := false
-
-	word, matched = checkReplace(word, "icate", "ic", matched, mMoreZero)
-	word, matched = checkReplace(word, "ative", "", matched, mMoreZero)
-	word, matched = checkReplace(word, "alize", "al", matched, mMoreZero)
-	word, matched = checkReplace(word, "iciti", "ic", matched, mMoreZero)
-	word, matched = checkReplace(word, "ical", "ic", matched, mMoreZero)
-	word, matched = checkReplace(word, "ful", "", matched, mMoreZero)
-	word, matched = checkReplace(word, "ness", "", matched, mMoreZero)
-
-	return word
-}
-
-// More stemming as part of Step 4
-func step4(word string) string {
-	mMoreOne := func(stem string) bool {
-		return m(stem) > 1
-	}
-
-	matched := false
-
-	word, matched = checkReplace(word, "al", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ance", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ence", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "er", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ic", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "able", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ible", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ant", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ement", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ment", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ent", "", matched, mMoreOne)
-
-	word, matched = checkReplace(word, "ion", "", matched, func(stem string) bool {
-		return m(stem) > 1 && (stem[len(stem)-1] == 's' || stem[len(stem)-1] == 't')
-	})
-
-	word, matched = checkReplace(word, "ou", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ism", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ate", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "iti", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ous", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ive", "", matched, mMoreOne)
-	word, matched = checkReplace(word, "ize", "", matched, mMoreOne)
-
-	return word
-}
-
 // Step5 focuses on clean up (the paper splits it up to A and B but i've
 // combined it )
 func step5(word string) string {
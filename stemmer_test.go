@@ -45,4 +45,30 @@ func TestCorpus(t *testing.T) {
     if inScan.Err() != nil || outScan.Err() != nil {
         t.Fatalf("Could not open scanner for input or output test files [%s, %s]", inScan.Err(), outScan.Err())
     }
+}
+
+// BenchmarkStem runs Stem over the same ~23000 word corpus used by TestCorpus,
+// to track the cost of the step1a/step2/step3/step4 FSM suffix matching
+func BenchmarkStem(b *testing.B) {
+    input, err := os.Open("corpus/test_input.txt")
+    if err != nil {
+        b.Fatalf("Could not read input test file [%s]", err)
+    }
+    defer input.Close()
+
+    var words []string
+    scanner := bufio.NewScanner(input)
+    for scanner.Scan() {
+        words = append(words, scanner.Text())
+    }
+    if scanner.Err() != nil {
+        b.Fatalf("Could not read input test file [%s]", scanner.Err())
+    }
+
+    b.ResetTimer()
+    for i := 0; i < b.N; i++ {
+        for _, word := range words {
+            Stem(word)
+        }
+    }
 }
\ No newline at end of file